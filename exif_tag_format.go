@@ -0,0 +1,171 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	exifcommon "github.com/dsoprea/go-exif/v3/common"
+)
+
+// exifToExiftoolTagName maps go-exif's raw tag names to the names exiftool
+// uses for the same concept, so callers can use one vocabulary regardless
+// of which reader produced the metadata. go-exif keeps IFD0's TIFF-era
+// names ("ImageLength") and the Exif sub-IFD's names ("PixelXDimension",
+// "PixelYDimension") distinct; exiftool normalizes both into
+// ImageWidth/ImageHeight.
+var exifToExiftoolTagName = map[string]string{
+	"PixelXDimension": "ImageWidth",
+	"PixelYDimension": "ImageHeight",
+	"ImageLength":     "ImageHeight",
+}
+
+// fractionTagNames are rendered as an exiftool-style "N/M" fraction rather
+// than a decimal, matching exiftool's default ExposureTime formatting.
+var fractionTagNames = map[string]bool{
+	"ExposureTime": true,
+}
+
+// exiftoolTagName translates a go-exif tag name to its exiftool equivalent,
+// passing unrecognized names through unchanged.
+func exiftoolTagName(goExifName string) string {
+	if name, ok := exifToExiftoolTagName[goExifName]; ok {
+		return name
+	}
+	return goExifName
+}
+
+// formatExifValue renders a go-exif tag value as the string exiftool would
+// have produced for the same tag, so downstream parsers (parseExposureTime,
+// KeyValueMap.GetFloat/GetInt, ...) see the formats they already expect
+// instead of Go's default "%v" on a rational/array struct (e.g. "[{1 13}]").
+func formatExifValue(goExifName string, value interface{}) string {
+	switch v := value.(type) {
+	case []exifcommon.Rational:
+		return formatRationals(goExifName, v)
+	case []exifcommon.SignedRational:
+		decimals := make([]string, len(v))
+		for i, r := range v {
+			decimals[i] = signedRationalDecimalString(r)
+		}
+		return strings.Join(decimals, ",")
+	case []uint16:
+		parts := make([]string, len(v))
+		for i, n := range v {
+			parts[i] = strconv.FormatUint(uint64(n), 10)
+		}
+		return strings.Join(parts, ",")
+	case []uint32:
+		parts := make([]string, len(v))
+		for i, n := range v {
+			parts[i] = strconv.FormatUint(uint64(n), 10)
+		}
+		return strings.Join(parts, ",")
+	case []int32:
+		parts := make([]string, len(v))
+		for i, n := range v {
+			parts[i] = strconv.FormatInt(int64(n), 10)
+		}
+		return strings.Join(parts, ",")
+	case []byte:
+		return strings.TrimRight(string(v), "\x00")
+	case string:
+		return v
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// formatRationals renders a RATIONAL-typed tag's decoded components. A
+// 3-component GPSLatitude/GPSLongitude is collapsed into decimal degrees;
+// ExposureTime keeps its single rational as an "N/M" fraction; everything
+// else (FNumber, FocalLength, ApertureValue, ...) is rendered as a decimal.
+func formatRationals(goExifName string, rs []exifcommon.Rational) string {
+	if len(rs) == 3 && (goExifName == "GPSLatitude" || goExifName == "GPSLongitude") {
+		return strconv.FormatFloat(dmsRationalsToDecimal(rs), 'f', -1, 64)
+	}
+
+	if len(rs) == 1 && fractionTagNames[goExifName] {
+		return rationalFractionString(rs[0])
+	}
+
+	decimals := make([]string, len(rs))
+	for i, r := range rs {
+		decimals[i] = rationalDecimalString(r)
+	}
+
+	return strings.Join(decimals, ",")
+}
+
+func rationalDecimalString(r exifcommon.Rational) string {
+	if r.Denominator == 0 {
+		return "0"
+	}
+	return strconv.FormatFloat(float64(r.Numerator)/float64(r.Denominator), 'f', -1, 64)
+}
+
+func signedRationalDecimalString(r exifcommon.SignedRational) string {
+	if r.Denominator == 0 {
+		return "0"
+	}
+	return strconv.FormatFloat(float64(r.Numerator)/float64(r.Denominator), 'f', -1, 64)
+}
+
+func rationalFractionString(r exifcommon.Rational) string {
+	return fmt.Sprintf("%d/%d", r.Numerator, r.Denominator)
+}
+
+// dmsRationalsToDecimal converts a [degrees, minutes, seconds] RATIONAL
+// triplet, as GPSLatitude/GPSLongitude are encoded, into decimal degrees.
+func dmsRationalsToDecimal(rs []exifcommon.Rational) float64 {
+	deg := rationalFloat(rs[0])
+	min := rationalFloat(rs[1])
+	sec := rationalFloat(rs[2])
+
+	return deg + min/60 + sec/3600
+}
+
+func rationalFloat(r exifcommon.Rational) float64 {
+	if r.Denominator == 0 {
+		return 0
+	}
+	return float64(r.Numerator) / float64(r.Denominator)
+}
+
+// addGPSComposites derives the exiftool-style "GPSPosition" and formatted
+// "GPSAltitude" composites from the raw GPSLatitude/GPSLongitude/
+// GPSAltitude tags decoded by formatExifValue, mirroring what exiftool
+// computes internally. It is a no-op when the raw GPS tags aren't present.
+func addGPSComposites(kv KeyValueMap) {
+	lat, latOK := strconv.ParseFloat(kv.GetString("GPSLatitude"), 64)
+	lng, lngOK := strconv.ParseFloat(kv.GetString("GPSLongitude"), 64)
+
+	if latOK != nil || lngOK != nil {
+		return
+	}
+
+	latRef := kv.GetString("GPSLatitudeRef")
+	lngRef := kv.GetString("GPSLongitudeRef")
+
+	kv["GPSPosition"] = fmt.Sprintf("%s, %s", dmsString(lat, latRef), dmsString(lng, lngRef))
+
+	if altVal, err := strconv.ParseFloat(kv.GetString("GPSAltitude"), 64); err == nil {
+		ref := "Above Sea Level"
+		if kv.GetString("GPSAltitudeRef") == "1" {
+			ref = "Below Sea Level"
+		}
+		kv["GPSAltitude"] = fmt.Sprintf("%.1f m %s", altVal, ref)
+	}
+}
+
+// dmsString renders decimalDegrees in exiftool's default GPSPosition
+// component format, e.g. "41 deg 23' 49.44\" N".
+func dmsString(decimalDegrees float64, ref string) string {
+	deg := math.Floor(decimalDegrees)
+	minFull := (decimalDegrees - deg) * 60
+	min := math.Floor(minFull)
+	sec := (minFull - min) * 60
+
+	return fmt.Sprintf("%d deg %d' %.2f\" %s", int(deg), int(min), sec, ref)
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	tzf "github.com/ringsaturn/tzf"
+)
+
+// tzFinder resolves an IANA timezone name from a lat/lng pair using an
+// embedded polygon lookup, so no network access or system tzdata-by-location
+// service is required. It is initialized lazily on first use.
+var tzFinder tzf.F
+
+func timezoneFinder() (tzf.F, error) {
+	if tzFinder != nil {
+		return tzFinder, nil
+	}
+
+	finder, err := tzf.NewDefaultFinder()
+	if err != nil {
+		return nil, fmt.Errorf("error loading timezone finder: %w", err)
+	}
+
+	tzFinder = finder
+
+	return tzFinder, nil
+}
+
+// offsetTags are, in priority order, the EXIF tags that encode the
+// camera-configured UTC offset for DateTimeOriginal/ModifyDate/CreateDate
+// respectively. When present they are more reliable than a GPS lookup since
+// they reflect the timezone the camera itself was set to.
+var offsetTags = []string{"OffsetTimeOriginal", "OffsetTime", "OffsetTimeDigitized"}
+
+// GetLocalCreationTime returns the earliest creation date (see
+// GetEarliestCreationDate) expressed in the photo's local time, along with
+// that *time.Location. It prefers the camera-reported UTC offset
+// (OffsetTimeOriginal/OffsetTime/OffsetTimeDigitized) when present, and
+// falls back to a GPS coordinate timezone lookup otherwise.
+func (meta *ExifToolMetadata) GetLocalCreationTime() (time.Time, *time.Location, error) {
+	naive := meta.GetEarliestCreationDate()
+
+	if loc, err := meta.offsetLocation(); err == nil {
+		return reinterpretIn(naive, loc), loc, nil
+	}
+
+	gps := meta.GetGPSData()
+	if gps.Latitude == 0 && gps.Longitude == 0 {
+		return naive, time.UTC, fmt.Errorf("no OffsetTime tag or GPS coordinates to resolve a timezone")
+	}
+
+	loc, err := locationAt(gps.Latitude, gps.Longitude)
+	if err != nil {
+		return naive, time.UTC, err
+	}
+
+	return reinterpretIn(naive, loc), loc, nil
+}
+
+// reinterpretIn re-anchors naive's wall-clock digits (year through
+// nanosecond) to loc, instead of converting the absolute instant the way
+// Time.In does. GetEarliestCreationDate's underlying time.Parse has no zone
+// in its layout, so naive's wall-clock digits are the camera-local time
+// misattributed to UTC; what we want is those same digits in loc, not the
+// same instant displayed in loc.
+func reinterpretIn(naive time.Time, loc *time.Location) time.Time {
+	return time.Date(
+		naive.Year(), naive.Month(), naive.Day(),
+		naive.Hour(), naive.Minute(), naive.Second(), naive.Nanosecond(),
+		loc,
+	)
+}
+
+// offsetLocation builds a fixed *time.Location from whichever of
+// offsetTags is present on meta, in priority order.
+func (meta *ExifToolMetadata) offsetLocation() (*time.Location, error) {
+	for _, tag := range offsetTags {
+		val := meta.Get(tag)
+		if val == "" {
+			continue
+		}
+
+		loc, err := parseUTCOffset(val)
+		if err != nil {
+			continue
+		}
+
+		return loc, nil
+	}
+
+	return nil, fmt.Errorf("no offset tag present")
+}
+
+// parseUTCOffset parses an EXIF offset string such as "+02:00" or "-07:00"
+// into a fixed *time.Location.
+func parseUTCOffset(offset string) (*time.Location, error) {
+	t, err := time.Parse("-07:00", offset)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing UTC offset %q: %w", offset, err)
+	}
+
+	_, secondsEastOfUTC := t.Zone()
+
+	return time.FixedZone(offset, secondsEastOfUTC), nil
+}
+
+// locationAt looks up the IANA timezone at lat/lng and returns it as a
+// *time.Location.
+func locationAt(lat, lng float64) (*time.Location, error) {
+	finder, err := timezoneFinder()
+	if err != nil {
+		return nil, err
+	}
+
+	name := finder.GetTimezoneName(lng, lat)
+	if name == "" {
+		return nil, fmt.Errorf("no timezone found for coordinates %f,%f", lat, lng)
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("error loading location %q: %w", name, err)
+	}
+
+	return loc, nil
+}
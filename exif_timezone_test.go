@@ -0,0 +1,57 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseUTCOffset(t *testing.T) {
+	cases := []struct {
+		offset      string
+		wantSeconds int
+		wantErr     bool
+	}{
+		{"+02:00", 2 * 3600, false},
+		{"-07:00", -7 * 3600, false},
+		{"+00:00", 0, false},
+		{"not-an-offset", 0, true},
+	}
+
+	for _, c := range cases {
+		loc, err := parseUTCOffset(c.offset)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("parseUTCOffset(%q): expected error, got none", c.offset)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("parseUTCOffset(%q): unexpected error: %v", c.offset, err)
+		}
+
+		_, gotSeconds := time.Date(2024, 1, 1, 0, 0, 0, 0, loc).Zone()
+		if gotSeconds != c.wantSeconds {
+			t.Errorf("parseUTCOffset(%q): got %d seconds east of UTC, want %d", c.offset, gotSeconds, c.wantSeconds)
+		}
+	}
+}
+
+func TestReinterpretIn(t *testing.T) {
+	naive := time.Date(2023, time.June, 1, 14, 30, 0, 0, time.UTC)
+	loc := time.FixedZone("+02:00", 2*3600)
+
+	got := reinterpretIn(naive, loc)
+
+	if got.Hour() != 14 || got.Minute() != 30 {
+		t.Fatalf("reinterpretIn: wall clock changed, got %s, want 14:30 in %s", got, loc)
+	}
+
+	if got.Location() != loc {
+		t.Fatalf("reinterpretIn: got location %v, want %v", got.Location(), loc)
+	}
+
+	if gotUTC := got.UTC(); gotUTC.Hour() != 12 || gotUTC.Minute() != 30 {
+		t.Fatalf("reinterpretIn: got %s in UTC, want 12:30 UTC", gotUTC)
+	}
+}
@@ -19,6 +19,13 @@ type ExifToolMetadata struct {
 	SourceFile  string
 	DataMap     KeyValueMap
 	DataMapJson string
+
+	// groupNames and dateFormat mirror the ExiftoolConfig the owning
+	// Exiftool was reading with when this metadata was produced, so Get,
+	// GetInt and GetTime can parse keys/values in whatever format -g/-d
+	// actually asked exiftool to emit.
+	groupNames bool
+	dateFormat string
 }
 
 type ExiftoolConfig struct {
@@ -29,6 +36,17 @@ type ExiftoolConfig struct {
 	bufferCloseArgs  []string
 	dataExtractArgs  []string
 	executeArg       string
+
+	// excludeTags, includeTags and extraExtractArgs feed into dataExtractArgs
+	// once all options have been applied; see buildDataExtractArgs.
+	excludeTags      []string
+	includeTags      []string
+	commonArgs       []string
+	apiOptions       map[string]string
+	charset          string
+	dateFormat       string
+	groupNames       bool
+	extraExtractArgs []string
 }
 
 type ExiftoolIO struct {
@@ -47,6 +65,13 @@ type Exiftool struct {
 }
 
 func (et *Exiftool) UseDefaults() {
+	et.config = defaultExiftoolConfig()
+	et.config.dataExtractArgs = et.config.buildDataExtractArgs()
+}
+
+// defaultExiftoolConfig returns the ExiftoolConfig used when no options are
+// passed to NewExiftool, and by the legacy UseDefaults path.
+func defaultExiftoolConfig() ExiftoolConfig {
 	var readyToken []byte
 
 	if runtime.GOOS == "windows" {
@@ -55,28 +80,65 @@ func (et *Exiftool) UseDefaults() {
 		readyToken = []byte("{ready}\n")
 	}
 
-	et.config = ExiftoolConfig{
+	return ExiftoolConfig{
 		executable:       "exiftool",
 		bufferOpenArgs:   []string{"-stay_open", "True", "-@", "-", "-common_args"},
 		readyToken:       readyToken,
 		readyTokenLength: len(readyToken),
 		bufferCloseArgs:  []string{"-stay_open", "False", "-execute"},
-		dataExtractArgs: []string{
-			"-json",
-			"-api", "largefilesupport=1",
-			"-extractEmbedded",
-			// exclude these tags (which can be very big strings)
-			"-x", "HistoryChanged",
-			"-x", "HistoryWhen",
-			"-x", "HistorySoftwareAgent",
-			"-x", "HistoryInstanceID",
-			"-x", "HistoryAction",
-			"-x", "ThumbnailImage",
+		executeArg:       "-execute",
+		apiOptions:       map[string]string{"largefilesupport": "1"},
+		// exclude these tags by default (they can be very big strings)
+		excludeTags: []string{
+			"HistoryChanged",
+			"HistoryWhen",
+			"HistorySoftwareAgent",
+			"HistoryInstanceID",
+			"HistoryAction",
+			"ThumbnailImage",
 		},
-		executeArg: "-execute",
 	}
 }
 
+// buildDataExtractArgs assembles the exiftool argument list used for every
+// ReadMetadata call from the config's include/exclude tags, API options and
+// formatting flags. It is computed once, after all options have been applied
+// (by NewExiftool, or by UseDefaults).
+func (c *ExiftoolConfig) buildDataExtractArgs() []string {
+	args := []string{"-json"}
+
+	for key, value := range c.apiOptions {
+		args = append(args, "-api", fmt.Sprintf("%s=%s", key, value))
+	}
+
+	args = append(args, "-extractEmbedded")
+
+	for _, tag := range c.excludeTags {
+		args = append(args, "-x", tag)
+	}
+
+	for _, tag := range c.includeTags {
+		args = append(args, "-"+tag)
+	}
+
+	if c.charset != "" {
+		args = append(args, "-charset", c.charset)
+	}
+
+	if c.dateFormat != "" {
+		args = append(args, "-d", c.dateFormat)
+	}
+
+	if c.groupNames {
+		args = append(args, "-G")
+	}
+
+	args = append(args, c.commonArgs...)
+	args = append(args, c.extraExtractArgs...)
+
+	return args
+}
+
 func (et *Exiftool) Open() error {
 	if et.config.executable == "" {
 		et.UseDefaults()
@@ -155,6 +217,8 @@ func (et *Exiftool) ReadMetadata(file string) (ExifToolMetadata, error) {
 
 	meta := ExifToolMetadata{}
 	meta.SourceFile = file
+	meta.groupNames = et.config.groupNames
+	meta.dateFormat = et.config.dateFormat
 	meta.Parse([]byte("{}"))
 
 	for _, dataExtractArg := range et.config.dataExtractArgs {
@@ -177,12 +241,31 @@ func (et *Exiftool) ReadMetadata(file string) (ExifToolMetadata, error) {
 	return meta, err
 }
 
+// Get looks up key in the raw tag map. When the owning Exiftool was
+// configured with WithGroupNames(true), exiftool prefixes every tag with
+// its group (e.g. "EXIF:DateTimeOriginal"), so a plain lookup is retried
+// against any key ending in ":"+key.
 func (meta *ExifToolMetadata) Get(key string) string {
-	return meta.DataMap.GetString(key)
+	if val := meta.DataMap.GetString(key); val != "" || !meta.groupNames {
+		return val
+	}
+
+	suffix := ":" + key
+	for k, v := range meta.DataMap {
+		if strings.HasSuffix(k, suffix) {
+			return fmt.Sprintf("%v", v)
+		}
+	}
+
+	return ""
 }
 
 func (meta *ExifToolMetadata) GetInt(key string) int {
-	return meta.DataMap.GetInt(key)
+	if !meta.groupNames {
+		return meta.DataMap.GetInt(key)
+	}
+
+	return int(ParseFloatOrZero(meta.Get(key)))
 }
 
 var ZeroDateError = fmt.Errorf("zero-date string")
@@ -192,6 +275,18 @@ func (meta *ExifToolMetadata) GetTime(key string) (time.Time, error) {
 	if val == "" || !regexp.MustCompile("(?i)^[1-9]").MatchString(val) {
 		return time.Time{}, ZeroDateError
 	}
+
+	if meta.dateFormat != "" {
+		layout := exiftoolDateFormatToGoLayout(meta.dateFormat)
+		date, err := time.Parse(layout, val)
+
+		if IsError(err) {
+			return date, fmt.Errorf("\n\nparsing time error formatting '%s' as '%s' (from exiftool date format %q) \n%s", val, layout, meta.dateFormat, err.Error())
+		}
+
+		return date, nil
+	}
+
 	dateFormat, normalizedDate := NormalizeTimestampStringFormat(val)
 	date, err := time.Parse(dateFormat, normalizedDate)
 
@@ -331,4 +426,4 @@ func (meta *ExifToolMetadata) GetFullCameraName() string {
 	}
 
 	return strings.TrimSpace(str)
-}
\ No newline at end of file
+}
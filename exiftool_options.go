@@ -0,0 +1,168 @@
+package main
+
+import "strings"
+
+// Option configures an Exiftool before it is opened. See WithExecutable,
+// WithExcludeTags, WithIncludeTags, WithCommonArgs, WithBuffer, WithCharset,
+// WithAPIOption, WithExtraExtractArgs, WithDateFormat and WithGroupNames.
+//
+// There is deliberately no WithCoordFormat: GetGPSData parses "GPSPosition"
+// through the external GPSData.Parse, which assumes exiftool's default
+// coordinate format and has no way to be told a different one was
+// requested. Exposing an option that silently broke GPS parsing was worse
+// than not having it; reach for WithExtraExtractArgs("-c", format) only if
+// you also stop calling GetGPSData.
+type Option func(*Exiftool)
+
+// NewExiftool builds an Exiftool from defaultExiftoolConfig, applies opts on
+// top, opens the underlying process and returns the ready-to-use instance.
+func NewExiftool(opts ...Option) (*Exiftool, error) {
+	et := &Exiftool{config: defaultExiftoolConfig()}
+
+	for _, opt := range opts {
+		opt(et)
+	}
+
+	et.config.dataExtractArgs = et.config.buildDataExtractArgs()
+
+	if err := et.Open(); err != nil {
+		return nil, err
+	}
+
+	return et, nil
+}
+
+// WithExecutable sets the path to the exiftool binary. Defaults to
+// "exiftool", resolved against $PATH.
+func WithExecutable(path string) Option {
+	return func(et *Exiftool) {
+		et.config.executable = path
+	}
+}
+
+// WithExcludeTags replaces the default set of tags excluded from every
+// extraction (e.g. large embedded history/thumbnail blobs) with tags.
+func WithExcludeTags(tags ...string) Option {
+	return func(et *Exiftool) {
+		et.config.excludeTags = tags
+	}
+}
+
+// WithIncludeTags restricts extraction to only the given tags, as exiftool's
+// "-TagName" (no "-x") selects.
+func WithIncludeTags(tags ...string) Option {
+	return func(et *Exiftool) {
+		et.config.includeTags = tags
+	}
+}
+
+// WithCommonArgs appends raw exiftool arguments that are passed on every
+// invocation (read and write).
+func WithCommonArgs(args ...string) Option {
+	return func(et *Exiftool) {
+		et.config.commonArgs = append(et.config.commonArgs, args...)
+	}
+}
+
+// WithBuffer sets the scan buffer used to read exiftool's stdout/stderr,
+// useful when extracted JSON for a single file can exceed bufio.Scanner's
+// default 64KiB limit.
+func WithBuffer(buf []byte, max int) Option {
+	return func(et *Exiftool) {
+		et.io.bufferSet = true
+		et.io.buffer = buf
+		et.io.bufferMaxSize = max
+	}
+}
+
+// WithCharset sets the charset exiftool should assume for file names and
+// extracted string values (exiftool's "-charset").
+func WithCharset(charset string) Option {
+	return func(et *Exiftool) {
+		et.config.charset = charset
+	}
+}
+
+// WithAPIOption sets an exiftool "-api key=value" option, e.g.
+// WithAPIOption("largefilesupport", "1"). Repeated calls accumulate.
+func WithAPIOption(key, value string) Option {
+	return func(et *Exiftool) {
+		if et.config.apiOptions == nil {
+			et.config.apiOptions = map[string]string{}
+		}
+		et.config.apiOptions[key] = value
+	}
+}
+
+// WithExtraExtractArgs appends raw exiftool arguments to the end of the
+// extraction argument list, for flags not otherwise covered by an Option.
+func WithExtraExtractArgs(args ...string) Option {
+	return func(et *Exiftool) {
+		et.config.extraExtractArgs = append(et.config.extraExtractArgs, args...)
+	}
+}
+
+// WithDateFormat sets exiftool's "-d" date format string, which controls how
+// date tags are formatted. GetTime converts the same format string into a Go
+// reference-time layout (see exiftoolDateFormatToGoLayout) so it keeps
+// parsing dates read with this option set. Only the directives in
+// strftimeToGoToken are translated, and GetTime treats any value that does
+// not begin with a digit 1-9 as a zero date, so a format that starts with a
+// zero-padded field (e.g. "%m/%d/%Y" for January) is not parsed.
+func WithDateFormat(format string) Option {
+	return func(et *Exiftool) {
+		et.config.dateFormat = format
+	}
+}
+
+// WithGroupNames enables exiftool's "-G" flag, prefixing each tag name with
+// its group (e.g. "EXIF:DateTimeOriginal" instead of "DateTimeOriginal").
+// Get, GetInt and GetTime all look up keys through Get, which retries a
+// group-prefixed match when this is enabled, so existing key names keep
+// working.
+func WithGroupNames(enabled bool) Option {
+	return func(et *Exiftool) {
+		et.config.groupNames = enabled
+	}
+}
+
+// strftimeToGoToken maps the subset of exiftool's (C strftime-derived) "-d"
+// format directives we support to their Go reference-time equivalents.
+var strftimeToGoToken = map[string]string{
+	"%Y": "2006",
+	"%y": "06",
+	"%m": "01",
+	"%d": "02",
+	"%H": "15",
+	"%M": "04",
+	"%S": "05",
+	"%%": "%",
+}
+
+// exiftoolDateFormatToGoLayout converts an exiftool "-d" format string (e.g.
+// "%Y:%m:%d %H:%M:%S") into the equivalent Go time.Parse reference layout.
+// It scans left to right and consumes each "%X" directive as a single unit,
+// rather than doing repeated whole-string replacement: the latter would
+// revisit text a previous replacement just produced (map iteration order
+// is randomized, so "%%Y" could see "%%"->"%" applied before or after
+// "%Y"->"2006", yielding a different, wrong layout from run to run for the
+// same input). Directives outside strftimeToGoToken are passed through
+// unchanged, so literal separators (":", " ", "-", ...) require no
+// translation.
+func exiftoolDateFormatToGoLayout(format string) string {
+	var layout strings.Builder
+
+	for i := 0; i < len(format); i++ {
+		if format[i] == '%' && i+1 < len(format) {
+			if token, ok := strftimeToGoToken[format[i:i+2]]; ok {
+				layout.WriteString(token)
+				i++
+				continue
+			}
+		}
+
+		layout.WriteByte(format[i])
+	}
+
+	return layout.String()
+}
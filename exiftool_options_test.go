@@ -0,0 +1,35 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildDataExtractArgsGroupAndDateFormat(t *testing.T) {
+	cfg := defaultExiftoolConfig()
+	cfg.groupNames = true
+	cfg.dateFormat = "%Y:%m:%d %H:%M:%S"
+
+	args := cfg.buildDataExtractArgs()
+	joined := strings.Join(args, " ")
+
+	if !strings.Contains(joined, "-G") {
+		t.Errorf("buildDataExtractArgs: missing -G, got %v", args)
+	}
+	if !strings.Contains(joined, "-d "+cfg.dateFormat) {
+		t.Errorf("buildDataExtractArgs: missing -d %s, got %v", cfg.dateFormat, args)
+	}
+}
+
+func TestExiftoolDateFormatToGoLayout(t *testing.T) {
+	cases := map[string]string{
+		"%Y:%m:%d %H:%M:%S": "2006:01:02 15:04:05",
+		"%Y-%m-%d":          "2006-01-02",
+	}
+
+	for in, want := range cases {
+		if got := exiftoolDateFormatToGoLayout(in); got != want {
+			t.Errorf("exiftoolDateFormatToGoLayout(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
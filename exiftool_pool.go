@@ -0,0 +1,144 @@
+package main
+
+import "sync"
+
+// poolJob is a unit of work dispatched to an idle worker in an ExiftoolPool.
+type poolJob struct {
+	index  int
+	file   string
+	result chan<- poolResult
+}
+
+// poolResult carries a worker's outcome back to the dispatcher, tagged with
+// the original index so ReadMetadataBatch can preserve input order.
+type poolResult struct {
+	index int
+	meta  ExifToolMetadata
+	err   error
+}
+
+// ExiftoolPool manages N long-lived workers and dispatches reads to
+// whichever one is idle, amortizing exiftool startup cost across a batch
+// while extraction itself runs in parallel across CPU cores. Workers are
+// held as MetadataReader rather than *Exiftool so tests can substitute a
+// fake reader instead of shelling out to a real exiftool binary.
+type ExiftoolPool struct {
+	workers []MetadataReader
+	closers []func() error
+	jobs    chan poolJob
+	wg      sync.WaitGroup
+}
+
+// NewExiftoolPool starts size exiftool workers and returns a pool ready to
+// accept work via ReadMetadata or ReadMetadataBatch.
+func NewExiftoolPool(size int) (*ExiftoolPool, error) {
+	if size < 1 {
+		size = 1
+	}
+
+	workers := make([]MetadataReader, 0, size)
+	closers := make([]func() error, 0, size)
+
+	for i := 0; i < size; i++ {
+		worker := &Exiftool{}
+		if err := worker.Open(); err != nil {
+			for _, closeFn := range closers {
+				closeFn()
+			}
+			return nil, err
+		}
+		workers = append(workers, worker)
+		closers = append(closers, worker.Close)
+	}
+
+	return newExiftoolPool(workers, closers), nil
+}
+
+// newExiftoolPool builds a pool from already-initialized workers and their
+// matching close functions. It is the seam NewExiftoolPool builds on top of
+// for real exiftool processes, and that tests use directly to substitute a
+// fake MetadataReader.
+func newExiftoolPool(workers []MetadataReader, closers []func() error) *ExiftoolPool {
+	pool := &ExiftoolPool{
+		workers: workers,
+		closers: closers,
+		jobs:    make(chan poolJob),
+	}
+
+	for _, worker := range pool.workers {
+		pool.wg.Add(1)
+		go pool.runWorker(worker)
+	}
+
+	return pool
+}
+
+// runWorker pulls jobs off the shared channel until it is closed, sending
+// each result back on the job's own result channel.
+func (pool *ExiftoolPool) runWorker(worker MetadataReader) {
+	defer pool.wg.Done()
+
+	for job := range pool.jobs {
+		meta, err := worker.ReadMetadata(job.file)
+		job.result <- poolResult{index: job.index, meta: meta, err: err}
+	}
+}
+
+// ReadMetadata dispatches file to the first idle worker and blocks until it
+// is processed.
+func (pool *ExiftoolPool) ReadMetadata(file string) (ExifToolMetadata, error) {
+	result := make(chan poolResult, 1)
+	pool.jobs <- poolJob{file: file, result: result}
+	res := <-result
+
+	return res.meta, res.err
+}
+
+// PoolResult pairs a file path with the metadata (or error) extracted for it,
+// as returned by ReadMetadataBatch.
+type PoolResult struct {
+	File string
+	Meta ExifToolMetadata
+	Err  error
+}
+
+// ReadMetadataBatch fans files out across the pool's workers and returns one
+// PoolResult per input file, in the same order as files.
+func (pool *ExiftoolPool) ReadMetadataBatch(files []string) []PoolResult {
+	results := make([]PoolResult, len(files))
+	collected := make(chan poolResult, len(files))
+
+	for i, file := range files {
+		go func(i int, file string) {
+			pool.jobs <- poolJob{index: i, file: file, result: collected}
+		}(i, file)
+	}
+
+	for range files {
+		res := <-collected
+		results[res.index] = PoolResult{File: files[res.index], Meta: res.meta, Err: res.err}
+	}
+
+	return results
+}
+
+// Close shuts down every worker in the pool and waits for their goroutines
+// to exit. If anything went wrong while closing a worker, a non empty error
+// will be returned.
+func (pool *ExiftoolPool) Close() error {
+	close(pool.jobs)
+	pool.wg.Wait()
+
+	var errs []error
+	for _, closeFn := range pool.closers {
+		if err := closeFn(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs[0]
+	}
+
+	return nil
+}
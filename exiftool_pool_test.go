@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeMetadataReader is a MetadataReader stand-in so ExiftoolPool can be
+// tested without shelling out to a real exiftool binary.
+type fakeMetadataReader struct {
+	delays map[string]time.Duration
+
+	mu    sync.Mutex
+	calls int
+}
+
+func (r *fakeMetadataReader) ReadMetadata(file string) (ExifToolMetadata, error) {
+	r.mu.Lock()
+	r.calls++
+	r.mu.Unlock()
+
+	if d, ok := r.delays[file]; ok {
+		time.Sleep(d)
+	}
+
+	if file == "broken.jpg" {
+		return ExifToolMetadata{SourceFile: file}, fmt.Errorf("simulated read error for %s", file)
+	}
+
+	return ExifToolMetadata{SourceFile: file}, nil
+}
+
+func newFakePool(n int, delays map[string]time.Duration) (*ExiftoolPool, *fakeMetadataReader) {
+	reader := &fakeMetadataReader{delays: delays}
+
+	workers := make([]MetadataReader, n)
+	for i := range workers {
+		workers[i] = reader
+	}
+
+	return newExiftoolPool(workers, nil), reader
+}
+
+func TestExiftoolPoolReadMetadata(t *testing.T) {
+	pool, _ := newFakePool(2, nil)
+	defer pool.Close()
+
+	meta, err := pool.ReadMetadata("a.jpg")
+	if err != nil {
+		t.Fatalf("ReadMetadata: unexpected error: %v", err)
+	}
+	if meta.SourceFile != "a.jpg" {
+		t.Fatalf("ReadMetadata: got SourceFile %q, want %q", meta.SourceFile, "a.jpg")
+	}
+}
+
+func TestExiftoolPoolReadMetadataBatchPreservesOrder(t *testing.T) {
+	files := []string{"a.jpg", "b.jpg", "c.jpg", "d.jpg"}
+	delays := map[string]time.Duration{
+		"a.jpg": 30 * time.Millisecond,
+		"b.jpg": 5 * time.Millisecond,
+		"c.jpg": 20 * time.Millisecond,
+		"d.jpg": 1 * time.Millisecond,
+	}
+
+	pool, reader := newFakePool(2, delays)
+	defer pool.Close()
+
+	results := pool.ReadMetadataBatch(files)
+
+	if len(results) != len(files) {
+		t.Fatalf("got %d results, want %d", len(results), len(files))
+	}
+
+	for i, file := range files {
+		if results[i].File != file {
+			t.Errorf("results[%d].File = %q, want %q (batch did not preserve input order)", i, results[i].File, file)
+		}
+		if results[i].Err != nil {
+			t.Errorf("results[%d].Err = %v", i, results[i].Err)
+		}
+	}
+
+	if reader.calls != len(files) {
+		t.Errorf("got %d ReadMetadata calls, want %d", reader.calls, len(files))
+	}
+}
+
+func TestExiftoolPoolReadMetadataBatchSurfacesErrors(t *testing.T) {
+	files := []string{"a.jpg", "broken.jpg", "c.jpg"}
+	pool, _ := newFakePool(2, nil)
+	defer pool.Close()
+
+	results := pool.ReadMetadataBatch(files)
+
+	if results[1].Err == nil {
+		t.Fatalf("results[1].Err = nil, want an error for broken.jpg")
+	}
+	if results[0].Err != nil || results[2].Err != nil {
+		t.Fatalf("unexpected error in results: %+v", results)
+	}
+}
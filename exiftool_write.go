@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WriteOptions controls how WriteMetadata and WriteMetadataBatch invoke
+// exiftool when writing tags back to a file.
+type WriteOptions struct {
+	// OverwriteOriginal writes in place without keeping an "_original" backup.
+	OverwriteOriginal bool
+	// OverwriteOriginalInPlace behaves like OverwriteOriginal but rewrites the
+	// file without renaming it, preserving its inode/hard links.
+	OverwriteOriginalInPlace bool
+	// PreserveTimestamps keeps the filesystem mtime/atime unchanged (-P).
+	PreserveTimestamps bool
+}
+
+func (o WriteOptions) args() []string {
+	var args []string
+
+	if o.OverwriteOriginalInPlace {
+		args = append(args, "-overwrite_original_in_place")
+	} else if o.OverwriteOriginal {
+		args = append(args, "-overwrite_original")
+	}
+
+	if o.PreserveTimestamps {
+		args = append(args, "-P")
+	}
+
+	return args
+}
+
+// WriteMetadata sets tags on file via the stay-open exiftool process and
+// reports whether the write succeeded. Unlike ReadMetadata, the process does
+// not reply with JSON: it prints a line such as "1 image files updated" or
+// "0 image files updated" (plus an "Error: ..." line on failure), so the
+// success/failure is parsed out of that text instead.
+func (et *Exiftool) WriteMetadata(file string, tags KeyValueMap, opts WriteOptions) error {
+	et.io.lock.Lock()
+	defer et.io.lock.Unlock()
+
+	for _, arg := range opts.args() {
+		fmt.Fprintln(et.io.stdin, arg)
+	}
+
+	for _, arg := range et.config.commonArgs {
+		fmt.Fprintln(et.io.stdin, arg)
+	}
+
+	for key, value := range tags {
+		fmt.Fprintf(et.io.stdin, "-%s=%v\n", key, value)
+	}
+
+	fmt.Fprintln(et.io.stdin, file)
+	fmt.Fprintln(et.io.stdin, et.config.executeArg)
+
+	if !et.io.scanMergedOut.Scan() {
+		return fmt.Errorf("error writing exif data: %s", file)
+	}
+
+	if et.io.scanMergedOut.Err() != nil {
+		return fmt.Errorf("error while reading stdMergedOut: %w", et.io.scanMergedOut.Err())
+	}
+
+	return parseWriteResult(et.io.scanMergedOut.Bytes(), file)
+}
+
+// parseWriteResult inspects the accumulated output of a write command for
+// the "N image files updated" / "Error: ..." lines exiftool prints instead
+// of a JSON payload.
+func parseWriteResult(output []byte, file string) error {
+	text := string(output)
+
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "Error:") {
+			return fmt.Errorf("exiftool write error for %s: %s", file, strings.TrimPrefix(line, "Error:"))
+		}
+	}
+
+	if strings.Contains(text, "0 image files updated") {
+		return fmt.Errorf("exiftool reported no files updated for %s", file)
+	}
+
+	return nil
+}
+
+// WriteMetadataBatch writes tags to each of files in turn, returning a map
+// from file path to the error encountered writing it (absent or nil when
+// the write succeeded).
+func (et *Exiftool) WriteMetadataBatch(files []string, tags KeyValueMap, opts WriteOptions) map[string]error {
+	results := make(map[string]error, len(files))
+
+	for _, file := range files {
+		results[file] = et.WriteMetadata(file, tags, opts)
+	}
+
+	return results
+}
@@ -0,0 +1,25 @@
+package main
+
+import "testing"
+
+func TestParseWriteResult(t *testing.T) {
+	cases := []struct {
+		name    string
+		output  string
+		wantErr bool
+	}{
+		{"success", "1 image files updated\n", false},
+		{"nothing updated", "0 image files updated\n", true},
+		{"explicit error", "1 files failed condition\nError: File not found - foo.jpg\n", true},
+	}
+
+	for _, c := range cases {
+		err := parseWriteResult([]byte(c.output), "foo.jpg")
+		if c.wantErr && err == nil {
+			t.Errorf("%s: expected error, got none", c.name)
+		}
+		if !c.wantErr && err != nil {
+			t.Errorf("%s: unexpected error: %v", c.name, err)
+		}
+	}
+}
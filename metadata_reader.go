@@ -0,0 +1,200 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	exif "github.com/dsoprea/go-exif/v3"
+)
+
+// MetadataReader is implemented by anything that can extract metadata from a
+// media file into an ExifToolMetadata. Exiftool satisfies it by shelling out
+// to the exiftool binary; NativeMetadataReader and HybridReader provide
+// alternative (and partially overlapping) implementations.
+type MetadataReader interface {
+	ReadMetadata(file string) (ExifToolMetadata, error)
+}
+
+// unsupportedNativeExt lists file extensions the native reader cannot parse
+// and that must always be routed to exiftool.
+var unsupportedNativeExt = map[string]bool{
+	".heic": true,
+	".heif": true,
+	".mov":  true,
+	".cr2":  true,
+	".nef":  true,
+	".arw":  true,
+	".dng":  true,
+}
+
+// NativeMetadataReader reads EXIF tags directly in Go, without shelling out
+// to exiftool, plus XMP tags from a sidecar file when one is present next to
+// the source file. It supports JPEG, TIFF and PNG containers. IPTC is not
+// supported yet; files that only carry IPTC metadata should go through
+// Exiftool (see HybridReader).
+type NativeMetadataReader struct{}
+
+// NewNativeMetadataReader returns a ready to use NativeMetadataReader.
+func NewNativeMetadataReader() *NativeMetadataReader {
+	return &NativeMetadataReader{}
+}
+
+// ReadMetadata extracts EXIF tags, plus any XMP sidecar tags, from file
+// without exiftool.
+func (r *NativeMetadataReader) ReadMetadata(file string) (ExifToolMetadata, error) {
+	meta := ExifToolMetadata{SourceFile: file}
+	meta.Parse([]byte("{}"))
+
+	if unsupportedNativeExt[strings.ToLower(filepath.Ext(file))] {
+		return meta, fmt.Errorf("native reader: unsupported format for %s", file)
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return meta, fmt.Errorf("native reader: error reading %s: %w", file, err)
+	}
+
+	rawExif, err := exif.SearchAndExtractExif(data)
+	if err != nil {
+		return meta, fmt.Errorf("native reader: no exif segment in %s: %w", file, err)
+	}
+
+	rawTags, _, err := exif.GetFlatExifData(rawExif, nil)
+	if err != nil {
+		return meta, fmt.Errorf("native reader: error reading exif data from %s: %w", file, err)
+	}
+
+	kv := KeyValueMap{}
+	for _, tag := range rawTags {
+		kv[exiftoolTagName(tag.TagName)] = formatExifValue(tag.TagName, tag.Value)
+	}
+
+	addGPSComposites(kv)
+
+	if xmpTags, err := readXMPSidecar(file); err == nil {
+		for k, v := range xmpTags {
+			kv[k] = v
+		}
+	}
+
+	meta.DataMap = kv
+
+	return meta, nil
+}
+
+// readXMPSidecar reads tags from a .xmp sidecar file next to file, if one
+// exists. It returns an empty map and no error when there is no sidecar.
+func readXMPSidecar(file string) (KeyValueMap, error) {
+	sidecar := strings.TrimSuffix(file, filepath.Ext(file)) + ".xmp"
+
+	data, err := os.ReadFile(sidecar)
+	if os.IsNotExist(err) {
+		return KeyValueMap{}, nil
+	}
+	if err != nil {
+		return KeyValueMap{}, err
+	}
+
+	return parseXMPFile(data)
+}
+
+// parseXMPFile parses the RDF/XML of an XMP packet into a flat KeyValueMap,
+// keyed by the local (namespace-stripped) element and attribute names. XMP
+// commonly encodes simple scalar properties as attributes on
+// rdf:Description (e.g. xmp:Rating="5", dc:title via a child element), so
+// both attributes and leaf element text are collected.
+func parseXMPFile(data []byte) (KeyValueMap, error) {
+	kv := KeyValueMap{}
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+
+	var currentKey string
+	var textBuf strings.Builder
+
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return kv, fmt.Errorf("error parsing xmp: %w", err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			for _, attr := range t.Attr {
+				if attr.Value == "" {
+					continue
+				}
+				kv[attr.Name.Local] = attr.Value
+			}
+			currentKey = t.Name.Local
+			textBuf.Reset()
+		case xml.CharData:
+			textBuf.Write(t)
+		case xml.EndElement:
+			if text := strings.TrimSpace(textBuf.String()); text != "" && currentKey == t.Name.Local {
+				kv[currentKey] = text
+			}
+			textBuf.Reset()
+		}
+	}
+
+	return kv, nil
+}
+
+// HybridReader prefers NativeMetadataReader for speed and falls back to
+// Exiftool for formats the native path cannot handle, or when a native
+// parse comes back missing the tags callers rely on most.
+type HybridReader struct {
+	native   *NativeMetadataReader
+	fallback *Exiftool
+}
+
+// NewHybridReader builds a HybridReader backed by an already-open Exiftool
+// instance used as the fallback path.
+func NewHybridReader(fallback *Exiftool) *HybridReader {
+	return &HybridReader{
+		native:   NewNativeMetadataReader(),
+		fallback: fallback,
+	}
+}
+
+// ReadMetadata tries the native parser first and falls back to exiftool
+// when the format is unsupported or the native result is missing data a
+// caller would reasonably rely on.
+func (r *HybridReader) ReadMetadata(file string) (ExifToolMetadata, error) {
+	meta, err := r.native.ReadMetadata(file)
+	if err == nil && nativeResultIsUsable(meta.DataMap) {
+		return meta, nil
+	}
+
+	return r.fallback.ReadMetadata(file)
+}
+
+// nativeResultIsUsable reports whether a native parse produced what
+// ToTyped's callers actually rely on: a creation date and real image
+// dimensions, plus — when the raw GPS tags were present at all — a
+// successfully computed GPSPosition composite. DateTimeOriginal alone is
+// not enough: it's an ASCII string that survives native parsing intact
+// even when width/height/GPS did not translate correctly, so checking it
+// in isolation let broken dimension/GPS data through undetected.
+func nativeResultIsUsable(data KeyValueMap) bool {
+	if data.GetString("DateTimeOriginal") == "" && data.GetString("CreateDate") == "" {
+		return false
+	}
+
+	if data.GetInt("ImageWidth") == 0 || data.GetInt("ImageHeight") == 0 {
+		return false
+	}
+
+	if data.GetString("GPSLatitude") != "" && data.GetString("GPSPosition") == "" {
+		return false
+	}
+
+	return true
+}
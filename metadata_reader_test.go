@@ -0,0 +1,171 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	exifcommon "github.com/dsoprea/go-exif/v3/common"
+)
+
+func TestParseXMPFile(t *testing.T) {
+	xmp := `<?xpacket begin="" id="W5M0MpCehiHzreSzNTczkc9d"?>
+<x:xmpmeta xmlns:x="adobe:ns:meta/">
+  <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+    <rdf:Description rdf:about="" xmlns:xmp="http://ns.adobe.com/xap/1.0/" xmp:Rating="5">
+      <dc:title xmlns:dc="http://purl.org/dc/elements/1.1/">Sunset over the bay</dc:title>
+    </rdf:Description>
+  </rdf:RDF>
+</x:xmpmeta>
+<?xpacket end="w"?>`
+
+	kv, err := parseXMPFile([]byte(xmp))
+	if err != nil {
+		t.Fatalf("parseXMPFile: unexpected error: %v", err)
+	}
+
+	if got := kv.GetString("Rating"); got != "5" {
+		t.Errorf("Rating = %q, want %q", got, "5")
+	}
+	if got := kv.GetString("title"); got != "Sunset over the bay" {
+		t.Errorf("title = %q, want %q", got, "Sunset over the bay")
+	}
+}
+
+func TestParseXMPFileInvalid(t *testing.T) {
+	if _, err := parseXMPFile([]byte("not xml at all <<<")); err == nil {
+		t.Fatalf("parseXMPFile: expected an error for malformed input, got none")
+	}
+}
+
+func rat(num, den uint32) exifcommon.Rational {
+	return exifcommon.Rational{Numerator: num, Denominator: den}
+}
+
+func TestExiftoolTagName(t *testing.T) {
+	cases := map[string]string{
+		"PixelXDimension": "ImageWidth",
+		"PixelYDimension": "ImageHeight",
+		"ImageLength":     "ImageHeight",
+		"Make":            "Make",
+	}
+
+	for in, want := range cases {
+		if got := exiftoolTagName(in); got != want {
+			t.Errorf("exiftoolTagName(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestFormatExifValueExposureTimeFraction(t *testing.T) {
+	got := formatExifValue("ExposureTime", []exifcommon.Rational{rat(1, 250)})
+	if got != "1/250" {
+		t.Errorf("formatExifValue(ExposureTime) = %q, want %q", got, "1/250")
+	}
+}
+
+func TestFormatExifValueDecimalRational(t *testing.T) {
+	got := formatExifValue("FNumber", []exifcommon.Rational{rat(28, 10)})
+	if got != "2.8" {
+		t.Errorf("formatExifValue(FNumber) = %q, want %q", got, "2.8")
+	}
+}
+
+func TestFormatExifValueGPSLatitudeDecimal(t *testing.T) {
+	// 41 deg 30' 0" -> 41.5
+	got := formatExifValue("GPSLatitude", []exifcommon.Rational{rat(41, 1), rat(30, 1), rat(0, 1)})
+	if got != "41.5" {
+		t.Errorf("formatExifValue(GPSLatitude) = %q, want %q", got, "41.5")
+	}
+}
+
+func TestFormatExifValueIntSlice(t *testing.T) {
+	if got := formatExifValue("Orientation", []uint16{1}); got != "1" {
+		t.Errorf("formatExifValue(Orientation) = %q, want %q", got, "1")
+	}
+}
+
+func TestAddGPSComposites(t *testing.T) {
+	kv := KeyValueMap{
+		"GPSLatitude":     "41.5",
+		"GPSLatitudeRef":  "N",
+		"GPSLongitude":    "2.1",
+		"GPSLongitudeRef": "E",
+		"GPSAltitude":     "12.3",
+		"GPSAltitudeRef":  "0",
+	}
+
+	addGPSComposites(kv)
+
+	pos := kv.GetString("GPSPosition")
+	if !strings.Contains(pos, "N") || !strings.Contains(pos, "E") {
+		t.Errorf("GPSPosition = %q, want it to mention both refs", pos)
+	}
+
+	if alt := kv.GetString("GPSAltitude"); alt != "12.3 m Above Sea Level" {
+		t.Errorf("GPSAltitude = %q, want %q", alt, "12.3 m Above Sea Level")
+	}
+}
+
+func TestAddGPSCompositesNoGPSTags(t *testing.T) {
+	kv := KeyValueMap{"Make": "Canon"}
+	addGPSComposites(kv)
+
+	if kv.GetString("GPSPosition") != "" {
+		t.Errorf("GPSPosition = %q, want empty when there are no raw GPS tags", kv.GetString("GPSPosition"))
+	}
+}
+
+func TestNativeResultIsUsable(t *testing.T) {
+	cases := []struct {
+		name string
+		data KeyValueMap
+		want bool
+	}{
+		{
+			name: "no date",
+			data: KeyValueMap{"ImageWidth": "100", "ImageHeight": "100"},
+			want: false,
+		},
+		{
+			name: "missing dimensions",
+			data: KeyValueMap{"DateTimeOriginal": "2023:06:01 14:30:00"},
+			want: false,
+		},
+		{
+			name: "gps present but composite missing",
+			data: KeyValueMap{
+				"DateTimeOriginal": "2023:06:01 14:30:00",
+				"ImageWidth":       "100",
+				"ImageHeight":      "100",
+				"GPSLatitude":      "41.5",
+			},
+			want: false,
+		},
+		{
+			name: "complete, no gps",
+			data: KeyValueMap{
+				"DateTimeOriginal": "2023:06:01 14:30:00",
+				"ImageWidth":       "100",
+				"ImageHeight":      "100",
+			},
+			want: true,
+		},
+		{
+			name: "complete with gps",
+			data: KeyValueMap{
+				"DateTimeOriginal": "2023:06:01 14:30:00",
+				"ImageWidth":       "100",
+				"ImageHeight":      "100",
+				"GPSLatitude":      "41.5",
+				"GPSPosition":      "41 deg 30' 0.00\" N, 2 deg 6' 0.00\" E",
+			},
+			want: true,
+		},
+	}
+
+	for _, c := range cases {
+		if got := nativeResultIsUsable(c.data); got != c.want {
+			t.Errorf("%s: nativeResultIsUsable() = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
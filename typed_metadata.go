@@ -0,0 +1,152 @@
+package main
+
+import (
+	"strings"
+	"time"
+)
+
+// TypedMetadata centralizes the ad-hoc tag scraping spread across
+// GetFullCameraName, GetMediaDuration, GetGPSData and friends into a single
+// strongly-typed struct, produced once per file by ToTyped.
+type TypedMetadata struct {
+	TakenAt      time.Time
+	TakenAtLocal time.Time
+	TimeZone     string
+
+	Lat, Lng float64
+	Altitude float64
+
+	Width, Height int
+	Orientation   int
+	Duration      time.Duration
+
+	CameraMake  string
+	CameraModel string
+	LensModel   string
+
+	Aperture     float64
+	ExposureTime float64
+	FocalLength  float64
+	ISO          int
+
+	Codec string
+
+	Keywords    []string
+	Title       string
+	Description string
+	Copyright   string
+}
+
+// ToTyped populates a TypedMetadata from meta's raw KeyValueMap, resolving
+// the many exiftool variants of the same concept (e.g. Duration vs
+// MediaDuration vs TrackDuration) into a single field each.
+func (meta *ExifToolMetadata) ToTyped() TypedMetadata {
+	typed := TypedMetadata{
+		TakenAt:     meta.GetEarliestCreationDate(),
+		Width:       meta.GetMediaWidth(),
+		Height:      meta.GetMediaHeight(),
+		Orientation: meta.GetInt("Orientation"),
+		CameraMake:  meta.Get("Make"),
+		CameraModel: meta.Get("Model"),
+		LensModel:   meta.Get("LensModel"),
+		ISO:         meta.GetInt("ISO"),
+		Codec:       firstNonEmpty(meta.Get("CompressorID"), meta.Get("VideoCodec"), meta.Get("Codec")),
+		Title:       meta.Get("Title"),
+		Description: firstNonEmpty(meta.Get("Description"), meta.Get("ImageDescription"), meta.Get("Caption-Abstract")),
+		Copyright:   meta.Get("Copyright"),
+	}
+
+	if local, loc, err := meta.GetLocalCreationTime(); err == nil {
+		typed.TakenAtLocal = local
+		typed.TimeZone = loc.String()
+	}
+
+	gps := meta.GetGPSData()
+	typed.Lat = gps.Latitude
+	typed.Lng = gps.Longitude
+	typed.Altitude = gps.Altitude
+
+	typed.Duration = parseMediaDuration(meta.GetMediaDuration())
+	typed.Aperture = meta.DataMap.GetFloat("Aperture")
+	typed.ExposureTime = parseExposureTime(meta.Get("ExposureTime"))
+	typed.FocalLength = meta.DataMap.GetFloat("FocalLength")
+	typed.Keywords = splitKeywords(meta.Get("Keywords"))
+
+	return typed
+}
+
+// firstNonEmpty returns the first non-empty string among values, or "".
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// parseMediaDuration converts a duration string as returned by
+// GetMediaDuration (e.g. "12.34s", "0:01:23") into a time.Duration. A space
+// before the unit (e.g. "12.34 s") is not accepted and yields 0.
+func parseMediaDuration(s string) time.Duration {
+	if s == "" {
+		return 0
+	}
+
+	if strings.HasSuffix(s, "s") {
+		s = strings.TrimSuffix(s, "s")
+		if d, err := time.ParseDuration(s + "s"); err == nil {
+			return d
+		}
+	}
+
+	parts := strings.Split(s, ":")
+	if len(parts) == 3 {
+		if d, err := time.ParseDuration(parts[0] + "h" + parts[1] + "m" + parts[2] + "s"); err == nil {
+			return d
+		}
+	}
+
+	return 0
+}
+
+// parseExposureTime converts an exiftool exposure time string into seconds
+// as a float64. Fractional exposures below 1s are reported as "N/M" (e.g.
+// "1/250"); exposures of 1s or longer are reported as a bare number (e.g.
+// "30" or "2.5").
+func parseExposureTime(s string) float64 {
+	if s == "" {
+		return 0
+	}
+
+	parts := strings.SplitN(s, "/", 2)
+	if len(parts) != 2 {
+		return ParseFloatOrZero(s)
+	}
+
+	num := ParseFloatOrZero(parts[0])
+	den := ParseFloatOrZero(parts[1])
+	if den == 0 {
+		return 0
+	}
+
+	return num / den
+}
+
+// splitKeywords splits an exiftool Keywords value, which may be a single
+// comma-separated string, into a slice of individual keywords.
+func splitKeywords(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	raw := strings.Split(s, ",")
+	keywords := make([]string, 0, len(raw))
+	for _, k := range raw {
+		if k = strings.TrimSpace(k); k != "" {
+			keywords = append(keywords, k)
+		}
+	}
+
+	return keywords
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseExposureTime(t *testing.T) {
+	cases := []struct {
+		in   string
+		want float64
+	}{
+		{"", 0},
+		{"1/250", 1.0 / 250},
+		{"1/4000", 1.0 / 4000},
+		{"30", 30},
+		{"2.5", 2.5},
+	}
+
+	for _, c := range cases {
+		if got := parseExposureTime(c.in); got != c.want {
+			t.Errorf("parseExposureTime(%q) = %v, want %v", c.in, got, c.want)
+		}
+	}
+}
+
+func TestParseMediaDuration(t *testing.T) {
+	cases := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"", 0},
+		{"12.34 s", 0},
+		{"0:01:23", 83 * time.Second},
+	}
+
+	for _, c := range cases {
+		if got := parseMediaDuration(c.in); got != c.want {
+			t.Errorf("parseMediaDuration(%q) = %v, want %v", c.in, got, c.want)
+		}
+	}
+}
+
+func TestSplitKeywords(t *testing.T) {
+	got := splitKeywords("beach, sunset ,family")
+	want := []string{"beach", "sunset", "family"}
+
+	if len(got) != len(want) {
+		t.Fatalf("splitKeywords: got %v, want %v", got, want)
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("splitKeywords: got %v, want %v", got, want)
+		}
+	}
+
+	if got := splitKeywords(""); got != nil {
+		t.Fatalf("splitKeywords(\"\") = %v, want nil", got)
+	}
+}